@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path"
+
+	baseprinter "github.com/FalcoSuessgott/vkv/pkg/printer"
+	"github.com/FalcoSuessgott/vkv/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var errInvalidFormat = errors.New("invalid --format")
+
+type exportOptions struct {
+	EnginePath string `env:"ENGINE_PATH"`
+	Path       string `env:"PATH"`
+
+	OnlyKeys  bool `env:"ONLY_KEYS"`
+	OnlyPaths bool `env:"ONLY_PATHS"`
+
+	ShowValues     bool   `env:"SHOW_VALUES"`
+	MaxValueLength int    `env:"MAX_VALUE_LENGTH" envDefault:"12"`
+	Format         string `env:"FORMAT" envDefault:"yaml"`
+
+	K8sName       string `env:"K8S_NAME"`
+	K8sNamespace  string `env:"K8S_NAMESPACE"`
+	K8sStringData bool   `env:"K8S_STRING_DATA"`
+
+	MaskMode string `env:"MASK_MODE" envDefault:"asterisks"`
+	MaskSalt string `env:"MASK_SALT"`
+
+	Streaming bool `env:"STREAMING"`
+	ChunkSize int  `env:"CHUNK_SIZE" envDefault:"500"`
+}
+
+// maskMode translates the --mask-mode flag into a baseprinter.MaskMode.
+func (o *exportOptions) maskMode() baseprinter.MaskMode {
+	switch o.MaskMode {
+	case "fingerprint":
+		return baseprinter.MaskFingerprint
+	case "length-preserving":
+		return baseprinter.MaskLengthPreserving
+	default:
+		return baseprinter.MaskAsterisks
+	}
+}
+
+// NewExportCmd export subcommand.
+func NewExportCmd() *cobra.Command {
+	o := &exportOptions{}
+
+	if err := utils.ParseEnvs(envVarExportPrefix, o); err != nil {
+		log.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:           "export",
+		Short:         "export secrets as yaml, json, export, dotenv, a Kubernetes Secret, tfvars or HCL",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PreRunE:       o.validateFlags,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootPath, subPath := utils.HandleEnginePath(o.EnginePath, o.Path)
+
+			secrets, err := vaultClient.ListRecursive(rootPath, subPath, false)
+			if err != nil {
+				return err
+			}
+
+			tree := utils.UnflattenMap(utils.NormalizePath(path.Join(rootPath, subPath)), utils.ToMapStringInterface(secrets), o.EnginePath)
+
+			p := baseprinter.NewPrinter(
+				tree,
+				o.formatOption(),
+				baseprinter.CustomValueLength(o.MaxValueLength),
+				baseprinter.ShowSecrets(o.ShowValues),
+				baseprinter.OnlyKeys(o.OnlyKeys),
+				baseprinter.OnlyPaths(o.OnlyPaths),
+				baseprinter.K8sStringData(o.K8sStringData),
+				baseprinter.WithMaskMode(o.maskMode()),
+				baseprinter.WithMaskSalt(o.MaskSalt),
+				baseprinter.WithWriter(writer),
+				baseprinter.StreamingChunkSize(o.ChunkSize),
+				// applied after StreamingChunkSize, which implies streaming,
+				// so only --streaming turns chunked output on.
+				baseprinter.Streaming(o.Streaming),
+				baseprinter.WithContext(cmd.Context()),
+			)
+
+			return p.Out()
+		},
+	}
+
+	// Input
+	cmd.Flags().StringVarP(&o.Path, "path", "p", o.Path, "KVv2 Engine path (env: VKV_EXPORT_PATH)")
+	cmd.Flags().StringVarP(&o.EnginePath, "engine-path", "e", o.EnginePath, "engine path in case your KV-engine contains special characters such as \"/\", the path (-p) flag will then be appended if specified (\"<engine-path>/<path>\") (env: VKV_EXPORT_ENGINE_PATH)")
+
+	// Options
+	cmd.Flags().BoolVar(&o.OnlyKeys, "only-keys", o.OnlyKeys, "only print keys (env: VKV_EXPORT_ONLY_KEYS)")
+	cmd.Flags().BoolVar(&o.OnlyPaths, "only-paths", o.OnlyPaths, "only print paths (env: VKV_EXPORT_ONLY_PATHS)")
+	cmd.Flags().BoolVar(&o.ShowValues, "show-values", o.ShowValues, "don't mask values (env: VKV_EXPORT_SHOW_VALUES)")
+	cmd.Flags().IntVar(&o.MaxValueLength, "max-value-length", o.MaxValueLength, "maximum char length of values. Set to \"-1\" for disabling "+
+		"(env: VKV_EXPORT_MAX_VALUE_LENGTH)")
+	cmd.Flags().StringVar(&o.Format, "format", o.Format, "output format, one of: yaml, json, export, dotenv, k8s, tfvars, hcl (env: VKV_EXPORT_FORMAT)")
+	cmd.Flags().StringVar(&o.K8sName, "name", o.K8sName, "name of the generated Kubernetes Secret, required for --format=k8s (env: VKV_EXPORT_K8S_NAME)")
+	cmd.Flags().StringVar(&o.K8sNamespace, "namespace", o.K8sNamespace, "namespace of the generated Kubernetes Secret (env: VKV_EXPORT_K8S_NAMESPACE)")
+	cmd.Flags().BoolVar(&o.K8sStringData, "string-data", o.K8sStringData, "render the generated Kubernetes Secret's values under \"stringData\" instead of base64-encoded \"data\" "+
+		"(env: VKV_EXPORT_K8S_STRING_DATA)")
+	cmd.Flags().StringVar(&o.MaskMode, "mask-mode", o.MaskMode, "how to mask secret values, one of: asterisks, fingerprint, length-preserving (env: VKV_EXPORT_MASK_MODE)")
+	cmd.Flags().StringVar(&o.MaskSalt, "mask-salt", o.MaskSalt, "salt used for fingerprint masking, defaults to VKV_MASK_SALT or a random per-invocation salt (env: VKV_EXPORT_MASK_SALT)")
+
+	// --streaming bounds the yaml/json rendering side of a large export to
+	// --chunk-size top-level paths at a time instead of serializing the whole
+	// tree in one shot, and stops rendering early once the command's context
+	// is cancelled. Note that vaultClient.ListRecursive still fetches the whole tree before this
+	// command ever sees it, so this does not bound the initial Vault fetch
+	// itself - only the memory this command then holds while rendering it.
+	cmd.Flags().BoolVar(&o.Streaming, "streaming", o.Streaming, "render yaml/json output in chunks of --chunk-size instead of one document (env: VKV_EXPORT_STREAMING)")
+	cmd.Flags().IntVar(&o.ChunkSize, "chunk-size", o.ChunkSize, "top-level paths per chunk when --streaming is set (env: VKV_EXPORT_CHUNK_SIZE)")
+
+	return cmd
+}
+
+// formatOption translates --format into the baseprinter.Option that selects it.
+func (o *exportOptions) formatOption() baseprinter.Option {
+	switch o.Format {
+	case "json":
+		return baseprinter.ToJSON(true)
+	case "export":
+		return baseprinter.ToExportFormat(true)
+	case "dotenv":
+		return baseprinter.ToDotenv(true)
+	case "k8s":
+		return baseprinter.ToK8sSecret(o.K8sName, o.K8sNamespace)
+	case "tfvars":
+		return baseprinter.ToTFVars(true)
+	case "hcl":
+		return baseprinter.ToHCL(true)
+	default:
+		return baseprinter.ToYAML(true)
+	}
+}
+
+func (o *exportOptions) validateFlags(cmd *cobra.Command, args []string) error {
+	switch o.Format {
+	case "yaml", "json", "export", "dotenv", "k8s", "tfvars", "hcl":
+	default:
+		return fmt.Errorf("%w: %q (must be one of: yaml, json, export, dotenv, k8s, tfvars, hcl)", errInvalidFormat, o.Format)
+	}
+
+	if o.Format == "k8s" && o.K8sName == "" {
+		return fmt.Errorf("%w: %s", errInvalidFlagCombination, "--name is required for --format=k8s")
+	}
+
+	switch o.MaskMode {
+	case "asterisks", "fingerprint", "length-preserving":
+	default:
+		return fmt.Errorf("%w: %q (must be one of: asterisks, fingerprint, length-preserving)", errInvalidMaskMode, o.MaskMode)
+	}
+
+	if o.Streaming && o.ChunkSize <= 0 {
+		return fmt.Errorf("%w: %s", errInvalidFlagCombination, "--chunk-size must be > 0 when --streaming is set")
+	}
+
+	return nil
+}
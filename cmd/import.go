@@ -8,27 +8,61 @@ import (
 	"path"
 	"strings"
 
+	"github.com/FalcoSuessgott/vkv/pkg/backend"
 	"github.com/FalcoSuessgott/vkv/pkg/fs"
+	baseprinter "github.com/FalcoSuessgott/vkv/pkg/printer"
+	"github.com/FalcoSuessgott/vkv/pkg/printer/diff"
 	prt "github.com/FalcoSuessgott/vkv/pkg/printer/secret"
 	"github.com/FalcoSuessgott/vkv/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
+// ExitCodePendingChanges is the exit code callers should translate
+// ErrPendingChanges to, so `--dry-run` can be used as a CI gate
+// (e.g. `vkv import --dry-run --diff-format=json || exit-code-check`).
+const ExitCodePendingChanges = 2
+
+var (
+	errInvalidDiffFormat = errors.New("invalid --diff-format")
+	errInvalidMaskMode   = errors.New("invalid --mask-mode")
+
+	// ErrPendingChanges is returned by dry-run commands when the computed
+	// diff contains pending changes, instead of exiting the process
+	// directly. Callers of Execute (main) should map it to
+	// ExitCodePendingChanges via errors.Is.
+	ErrPendingChanges = errors.New("pending changes detected")
+)
+
 type importOptions struct {
 	EnginePath string `env:"ENGINE_PATH"`
 	Path       string `env:"PATH"`
 
 	File string `env:"FILE"`
 
-	Force          bool `env:"FORCE"`
-	DryRun         bool `env:"DRY_RUN"`
-	Silent         bool `env:"SILENT"`
-	ShowValues     bool `env:"SHOW_VALUES"`
-	MaxValueLength int  `env:"MAX_VALUE_LENGTH" envDefault:"12"`
+	Force          bool   `env:"FORCE"`
+	DryRun         bool   `env:"DRY_RUN"`
+	Silent         bool   `env:"SILENT"`
+	ShowValues     bool   `env:"SHOW_VALUES"`
+	MaxValueLength int    `env:"MAX_VALUE_LENGTH" envDefault:"12"`
+	DiffFormat     string `env:"DIFF_FORMAT" envDefault:"text"`
+	MaskMode       string `env:"MASK_MODE" envDefault:"asterisks"`
+	MaskSalt       string `env:"MASK_SALT"`
 
 	input io.Reader
 }
 
+// maskMode translates the --mask-mode flag into a baseprinter.MaskMode.
+func (o *importOptions) maskMode() baseprinter.MaskMode {
+	switch o.MaskMode {
+	case "fingerprint":
+		return baseprinter.MaskFingerprint
+	case "length-preserving":
+		return baseprinter.MaskLengthPreserving
+	default:
+		return baseprinter.MaskAsterisks
+	}
+}
+
 // NewImportCmd import subcommand.
 // nolint: cyclop, gocognit, lll
 func NewImportCmd() *cobra.Command {
@@ -79,6 +113,13 @@ func NewImportCmd() *cobra.Command {
 			// read existing secrets from the rootPath
 			rootPath, subPath := utils.HandleEnginePath(o.EnginePath, o.Path)
 
+			// resolve the mask salt once so the dry-run diff and every
+			// printer constructed below fingerprint the same value the
+			// same way, instead of each defaulting independently.
+			if o.MaskSalt == "" {
+				o.MaskSalt = baseprinter.DefaultMaskSalt()
+			}
+
 			printer = prt.NewSecretPrinter(
 				prt.CustomValueLength(o.MaxValueLength),
 				prt.ShowValues(o.ShowValues),
@@ -89,6 +130,8 @@ func NewImportCmd() *cobra.Command {
 				prt.ShowMetadata(true),
 				prt.ShowVersion(false),
 				prt.WithEnginePath(utils.NormalizePath(rootPath)),
+				prt.WithMaskMode(o.maskMode()),
+				prt.WithMaskSalt(o.MaskSalt),
 			)
 
 			// print preview during dryrun and exit
@@ -140,6 +183,9 @@ func NewImportCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&o.ShowValues, "show-values", o.ShowValues, "don't mask values (env: VKV_IMPORT_SHOW_VALUES)")
 	cmd.Flags().IntVar(&o.MaxValueLength, "max-value-length", o.MaxValueLength, "maximum char length of values. Set to \"-1\" for disabling "+
 		"(env: VKV_IMPORT_MAX_VALUE_LENGTH)")
+	cmd.Flags().StringVar(&o.DiffFormat, "diff-format", o.DiffFormat, "dry-run diff output format, one of: text, json, yaml (env: VKV_IMPORT_DIFF_FORMAT)")
+	cmd.Flags().StringVar(&o.MaskMode, "mask-mode", o.MaskMode, "how to mask secret values, one of: asterisks, fingerprint, length-preserving (env: VKV_IMPORT_MASK_MODE)")
+	cmd.Flags().StringVar(&o.MaskSalt, "mask-salt", o.MaskSalt, "salt used for fingerprint masking, defaults to VKV_MASK_SALT or a random per-invocation salt (env: VKV_IMPORT_MASK_SALT)")
 
 	o.input = cmd.InOrStdin()
 
@@ -158,6 +204,18 @@ func (o *importOptions) validateFlags(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	switch o.DiffFormat {
+	case diff.TextFormat, diff.JSONFormat, diff.YAMLFormat:
+	default:
+		return fmt.Errorf("%w: %q (must be one of: text, json, yaml)", errInvalidDiffFormat, o.DiffFormat)
+	}
+
+	switch o.MaskMode {
+	case "asterisks", "fingerprint", "length-preserving":
+	default:
+		return fmt.Errorf("%w: %q (must be one of: asterisks, fingerprint, length-preserving)", errInvalidMaskMode, o.MaskMode)
+	}
+
 	return nil
 }
 
@@ -206,29 +264,10 @@ func (o *importOptions) parseInput(input []byte) (map[string]interface{}, error)
 }
 
 func (o *importOptions) writeSecrets(rootPath, subPath string, secrets map[string]interface{}) error {
-	transformedMap := make(map[string]interface{})
-	utils.FlattenMap(secrets, transformedMap, "")
-
-	for p, m := range transformedMap {
-		secret, ok := m.(map[string]interface{})
-		if !ok {
-			log.Fatalf("cannot convert %T to map[string]interface", secret)
-		}
+	vb := backend.NewVaultBackend(vaultClient, rootPath, backend.WithVaultWriter(writer))
 
-		// replace original path with the new engine path
-		t, _ := utils.GetRootElement(secrets)
-		newSubPath := strings.TrimPrefix(p, t)
-
-		// unless a subpath has been specified by the user
-		if subPath != "" {
-			newSubPath = path.Join(subPath, newSubPath)
-		}
-
-		if err := vaultClient.WriteSecrets(rootPath, newSubPath, secret); err != nil {
-			return fmt.Errorf("error writing secret \"%s\": %w", p, err)
-		}
-
-		fmt.Fprintf(writer, "writing secret \"%s\" \n", path.Join(rootPath, newSubPath))
+	if err := vb.Write(subPath, secrets); err != nil {
+		return fmt.Errorf("error writing secrets to \"%s\": %w", path.Join(rootPath, subPath), err)
 	}
 
 	fmt.Fprintln(writer, "successfully imported all secrets")
@@ -250,32 +289,31 @@ func (o *importOptions) dryRun(rootPath, subPath string, secrets map[string]inte
 
 	existingSecrets := utils.UnflattenMap(utils.NormalizePath(path.Join(rootPath, subPath)), utils.ToMapStringInterface(tmp), o.EnginePath)
 
-	// check whether new and existing secrets are equal
-	if fmt.Sprint(secrets) == fmt.Sprint(existingSecrets) {
-		fmt.Fprintln(writer, "")
-		fmt.Fprintln(writer, "input matches secrets - no changes needed:")
-		fmt.Fprintln(writer, "")
-
-		if err := printer.Out(existingSecrets); err != nil {
-			return err
-		}
+	d := diff.New(
+		diff.ShowValues(o.ShowValues),
+		diff.Salt(o.MaskSalt),
+		diff.MaskMode(o.maskMode()),
+		diff.MaxValueLength(o.MaxValueLength),
+	)
 
-		return nil
-	}
+	records := d.Compare(existingSecrets, secrets)
 
-	fmt.Fprintf(writer, "deep merging provided secrets with existing secrets read from \"%s\"\n", utils.NormalizePath(path.Join(rootPath, subPath)))
 	fmt.Fprintln(writer, "")
-	fmt.Fprintln(writer, "preview:")
+	fmt.Fprintln(writer, "diff:")
 	fmt.Fprintln(writer, "")
 
-	if err := printer.Out(utils.DeepMergeMaps(secrets, existingSecrets)); err != nil {
+	if err := d.Print(writer, o.DiffFormat, records); err != nil {
 		return err
 	}
 
+	if !diff.HasChanges(records) {
+		return nil
+	}
+
 	fmt.Fprintln(writer, "")
 	fmt.Fprintln(writer, "apply changes by using the --force flag")
 
-	return nil
+	return ErrPendingChanges
 }
 
 func (o *importOptions) printResult(rootPath, subPath string) (map[string]interface{}, error) {
@@ -293,6 +331,8 @@ func (o *importOptions) printResult(rootPath, subPath string) (map[string]interf
 		prt.ShowMetadata(true),
 		prt.ShowVersion(true),
 		prt.WithEnginePath(utils.NormalizePath(rootPath)),
+		prt.WithMaskMode(o.maskMode()),
+		prt.WithMaskSalt(o.MaskSalt),
 	)
 
 	secrets, err := vaultClient.ListRecursive(rootPath, subPath, false)
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/FalcoSuessgott/vkv/pkg/backend"
+	prt "github.com/FalcoSuessgott/vkv/pkg/printer/secret"
+	"github.com/spf13/cobra"
+)
+
+const (
+	envVarImportPrefix = "VKV_IMPORT_"
+	envVarExportPrefix = "VKV_EXPORT_"
+	envVarSyncPrefix   = "VKV_SYNC_"
+)
+
+var errInvalidFlagCombination = errors.New("invalid flag combination")
+
+// VaultClient is the subset of vkv's Vault client every subcommand needs,
+// on top of the read/write surface backend.VaultClient already requires.
+type VaultClient interface {
+	backend.VaultClient
+	EnableKV2EngineErrorIfNotForced(force bool, rootPath string) error
+}
+
+// vaultClient is the Vault client every subcommand operates against. Execute
+// sets it before running the root command.
+var vaultClient VaultClient
+
+// writer is where every subcommand writes its human-readable output to.
+var writer io.Writer = os.Stdout
+
+// printer is the secret printer import.go's RunE (re)configures per
+// invocation to render the result of an import.
+var printer *prt.SecretPrinter
+
+// NewRootCmd returns vkv's root command with every subcommand registered.
+func NewRootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "vkv",
+		Short:         "list, import, export and sync Vault KV secrets",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(NewImportCmd(), NewExportCmd(), NewSyncCmd())
+
+	return cmd
+}
+
+// Execute runs the root command against client, mapping ErrPendingChanges to
+// ExitCodePendingChanges instead of cobra's default exit(1) for every error,
+// so e.g. `vkv import --dry-run` can gate CI on whether pending changes were
+// found.
+func Execute(client VaultClient) {
+	vaultClient = client
+
+	err := NewRootCmd().Execute()
+	if err == nil {
+		return
+	}
+
+	if errors.Is(err, ErrPendingChanges) {
+		os.Exit(ExitCodePendingChanges)
+	}
+
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
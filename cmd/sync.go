@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/FalcoSuessgott/vkv/pkg/backend"
+	"github.com/FalcoSuessgott/vkv/pkg/printer/diff"
+	"github.com/FalcoSuessgott/vkv/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type syncOptions struct {
+	From string `env:"FROM"`
+	To   string `env:"TO"`
+
+	Force      bool   `env:"FORCE"`
+	DryRun     bool   `env:"DRY_RUN"`
+	DiffFormat string `env:"DIFF_FORMAT" envDefault:"text"`
+}
+
+// NewSyncCmd sync subcommand.
+func NewSyncCmd() *cobra.Command {
+	o := &syncOptions{}
+
+	if err := utils.ParseEnvs(envVarSyncPrefix, o); err != nil {
+		log.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:           "sync",
+		Short:         "reconcile secrets between two backends (vault, onepassword, awssecretsmanager, gcpsecretmanager, sops)",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PreRunE:       o.validateFlags,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVar(&o.From, "from", o.From, "source backend URI, e.g. vault://secret/foo (env: VKV_SYNC_FROM)")
+	cmd.Flags().StringVar(&o.To, "to", o.To, "destination backend URI, e.g. onepassword://Engineering/db-creds (env: VKV_SYNC_TO)")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "apply the sync instead of just previewing it (env: VKV_SYNC_FORCE)")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "d", o.DryRun, "preview the sync without writing anything (env: VKV_SYNC_DRY_RUN)")
+	cmd.Flags().StringVar(&o.DiffFormat, "diff-format", o.DiffFormat, "dry-run diff output format, one of: text, json, yaml (env: VKV_SYNC_DIFF_FORMAT)")
+
+	return cmd
+}
+
+func (o *syncOptions) validateFlags(cmd *cobra.Command, args []string) error {
+	switch {
+	case o.From == "" || o.To == "":
+		return fmt.Errorf("%w: %s", errInvalidFlagCombination, "both --from and --to are required")
+	case o.Force && o.DryRun:
+		return fmt.Errorf("%w: %s", errInvalidFlagCombination, "cannot specify both --force and --dry-run")
+	}
+
+	switch o.DiffFormat {
+	case diff.TextFormat, diff.JSONFormat, diff.YAMLFormat:
+	default:
+		return fmt.Errorf("%w: %q (must be one of: text, json, yaml)", errInvalidDiffFormat, o.DiffFormat)
+	}
+
+	return nil
+}
+
+func (o *syncOptions) run() error {
+	src, srcPath, err := backend.New(o.From, vaultClient)
+	if err != nil {
+		return fmt.Errorf("resolving --from %q: %w", o.From, err)
+	}
+
+	dst, dstPath, err := backend.New(o.To, vaultClient)
+	if err != nil {
+		return fmt.Errorf("resolving --to %q: %w", o.To, err)
+	}
+
+	secrets, err := src.Read(srcPath)
+	if err != nil {
+		return fmt.Errorf("reading %q: %w", o.From, err)
+	}
+
+	existing, err := dst.Read(dstPath)
+	if err != nil {
+		return fmt.Errorf("reading %q: %w", o.To, err)
+	}
+
+	// diff.Compare and VaultBackend.Write both expect the two-level
+	// path -> {key: value} shape. backend.IsFlat backends (AWS/GCP Secret
+	// Manager, 1Password) instead return/accept a single secret's flat
+	// {key: value} fields, so wrap them under dstPath - the identity this
+	// sync is comparing/writing under - before handing them to that
+	// machinery, and unwrap again before a flat destination's Write.
+	if backend.IsFlat(src) {
+		secrets = map[string]interface{}{dstPath: secrets}
+	}
+
+	if backend.IsFlat(dst) {
+		existing = map[string]interface{}{dstPath: existing}
+	}
+
+	merged := utils.DeepMergeMaps(secrets, existing)
+
+	if o.DryRun || !o.Force {
+		return o.printDiff(existing, merged)
+	}
+
+	toWrite := merged
+	if backend.IsFlat(dst) {
+		toWrite, _ = merged[dstPath].(map[string]interface{})
+	}
+
+	if err := dst.Write(dstPath, toWrite); err != nil {
+		return fmt.Errorf("writing %q: %w", o.To, err)
+	}
+
+	fmt.Fprintf(writer, "successfully synced %q -> %q\n", o.From, o.To)
+
+	return nil
+}
+
+func (o *syncOptions) printDiff(existing, merged map[string]interface{}) error {
+	d := diff.New()
+	records := d.Compare(existing, merged)
+
+	fmt.Fprintln(writer, "diff:")
+	fmt.Fprintln(writer, "")
+
+	if err := d.Print(writer, o.DiffFormat, records); err != nil {
+		return err
+	}
+
+	if !diff.HasChanges(records) {
+		return nil
+	}
+
+	fmt.Fprintln(writer, "")
+	fmt.Fprintln(writer, "apply changes by using the --force flag")
+
+	return ErrPendingChanges
+}
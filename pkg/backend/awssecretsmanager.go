@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// AWSSecretsManagerBackend reads and writes secrets via AWS Secrets Manager,
+// storing vkv's map[string]interface{} as a single JSON-encoded secret value
+// per secret name.
+type AWSSecretsManagerBackend struct {
+	client *secretsmanager.Client
+}
+
+// NewAWSSecretsManagerBackend returns a Backend backed by AWS Secrets Manager in region.
+func NewAWSSecretsManagerBackend(ctx context.Context, region string) (*AWSSecretsManagerBackend, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	return &AWSSecretsManagerBackend{client: secretsmanager.NewFromConfig(cfg)}, nil
+}
+
+// Read returns the JSON-decoded secret named secretPath.
+func (b *AWSSecretsManagerBackend) Read(secretPath string) (map[string]interface{}, error) {
+	out, err := b.client.GetSecretValue(context.Background(), &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretPath),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting secret %q: %w", secretPath, err)
+	}
+
+	secrets := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(aws.ToString(out.SecretString)), &secrets); err != nil {
+		return nil, fmt.Errorf("parsing secret %q: %w", secretPath, err)
+	}
+
+	return secrets, nil
+}
+
+// Write JSON-encodes secrets and writes them to secretPath, creating the
+// secret first if it doesn't exist yet.
+func (b *AWSSecretsManagerBackend) Write(secretPath string, secrets map[string]interface{}) error {
+	ctx := context.Background()
+
+	value, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("encoding secret %q: %w", secretPath, err)
+	}
+
+	_, err = b.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secretPath),
+		SecretString: aws.String(string(value)),
+	})
+
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		_, err = b.client.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+			Name:         aws.String(secretPath),
+			SecretString: aws.String(string(value)),
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("writing secret %q: %w", secretPath, err)
+	}
+
+	return nil
+}
+
+// List returns the names of all secrets below prefix.
+func (b *AWSSecretsManagerBackend) List(prefix string) ([]string, error) {
+	var names []string
+
+	paginator := secretsmanager.NewListSecretsPaginator(b.client, &secretsmanager.ListSecretsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("listing secrets: %w", err)
+		}
+
+		for _, s := range page.SecretList {
+			if name := aws.ToString(s.Name); strings.HasPrefix(name, prefix) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names, nil
+}
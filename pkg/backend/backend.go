@@ -0,0 +1,33 @@
+// Package backend defines the minimal read/write/list contract vkv uses to
+// reconcile secrets across arbitrary secret stores. The existing Vault
+// client is just one implementation (VaultBackend); adapters for other
+// stores (1Password, AWS/GCP secret managers, SOPS) live alongside it so
+// `vkv sync` and `vkv import` can treat every store the same way.
+package backend
+
+// Backend is implemented by every secret store vkv can sync to/from. path is
+// always relative to whatever root the Backend was constructed with (a KV
+// engine, a 1Password vault, an AWS region, a SOPS file, ...).
+type Backend interface {
+	// Read returns the secrets stored at path.
+	Read(path string) (map[string]interface{}, error)
+	// Write overwrites the secrets stored at path.
+	Write(path string, secrets map[string]interface{}) error
+	// List returns the sub-paths stored below path.
+	List(path string) ([]string, error)
+}
+
+// IsFlat reports whether b's Read/Write operate on a single secret's flat
+// {key: value} fields (AWS Secrets Manager, GCP Secret Manager, 1Password)
+// rather than a tree of many paths (Vault, SOPS). Callers that feed a
+// Backend's secrets into machinery built for the two-level path ->
+// {key: value} shape - diff.Compare, utils.DeepMergeMaps, VaultBackend.Write -
+// must wrap/unwrap a flat backend's secrets under its own path first.
+func IsFlat(b Backend) bool {
+	switch b.(type) {
+	case *AWSSecretsManagerBackend, *GCPSecretManagerBackend, *OnePasswordBackend:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,25 @@
+package backend
+
+import "testing"
+
+func TestIsFlat(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Backend
+		want bool
+	}{
+		{"vault", &VaultBackend{}, false},
+		{"sops", &SOPSBackend{}, false},
+		{"aws secrets manager", &AWSSecretsManagerBackend{}, true},
+		{"gcp secret manager", &GCPSecretManagerBackend{}, true},
+		{"1password", &OnePasswordBackend{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsFlat(tt.b); got != tt.want {
+				t.Errorf("IsFlat(%s) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// GCPSecretManagerBackend reads and writes secrets via GCP Secret Manager,
+// storing vkv's map[string]interface{} as a single JSON-encoded secret
+// version per secret name.
+type GCPSecretManagerBackend struct {
+	client  *secretmanager.Client
+	project string
+}
+
+// NewGCPSecretManagerBackend returns a Backend backed by GCP Secret Manager in project.
+func NewGCPSecretManagerBackend(ctx context.Context, project string) (*GCPSecretManagerBackend, error) {
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCP Secret Manager client: %w", err)
+	}
+
+	return &GCPSecretManagerBackend{client: client, project: project}, nil
+}
+
+func (b *GCPSecretManagerBackend) secretName(name string) string {
+	return fmt.Sprintf("projects/%s/secrets/%s", b.project, name)
+}
+
+// Read returns the JSON-decoded latest version of the secret named secretPath.
+func (b *GCPSecretManagerBackend) Read(secretPath string) (map[string]interface{}, error) {
+	ctx := context.Background()
+
+	resp, err := b.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: b.secretName(secretPath) + "/versions/latest",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("accessing secret %q: %w", secretPath, err)
+	}
+
+	secrets := make(map[string]interface{})
+	if err := json.Unmarshal(resp.GetPayload().GetData(), &secrets); err != nil {
+		return nil, fmt.Errorf("parsing secret %q: %w", secretPath, err)
+	}
+
+	return secrets, nil
+}
+
+// Write adds a new secret version for secretPath, creating the secret first if needed.
+func (b *GCPSecretManagerBackend) Write(secretPath string, secrets map[string]interface{}) error {
+	ctx := context.Background()
+
+	value, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("encoding secret %q: %w", secretPath, err)
+	}
+
+	if _, err := b.client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{Name: b.secretName(secretPath)}); err != nil {
+		if _, err := b.client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+			Parent:   fmt.Sprintf("projects/%s", b.project),
+			SecretId: secretPath,
+			Secret: &secretmanagerpb.Secret{
+				Replication: &secretmanagerpb.Replication{
+					Replication: &secretmanagerpb.Replication_Automatic_{},
+				},
+			},
+		}); err != nil {
+			return fmt.Errorf("creating secret %q: %w", secretPath, err)
+		}
+	}
+
+	if _, err := b.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  b.secretName(secretPath),
+		Payload: &secretmanagerpb.SecretPayload{Data: value},
+	}); err != nil {
+		return fmt.Errorf("writing secret %q: %w", secretPath, err)
+	}
+
+	return nil
+}
+
+// List returns the names of all secrets in the project below prefix.
+func (b *GCPSecretManagerBackend) List(prefix string) ([]string, error) {
+	var names []string
+
+	it := b.client.ListSecrets(context.Background(), &secretmanagerpb.ListSecretsRequest{
+		Parent: fmt.Sprintf("projects/%s", b.project),
+	})
+
+	for {
+		secret, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("listing secrets: %w", err)
+		}
+
+		parts := strings.Split(secret.GetName(), "/")
+		name := parts[len(parts)-1]
+
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
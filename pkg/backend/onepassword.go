@@ -0,0 +1,104 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// OnePasswordBackend reads and writes items via the 1Password `op` CLI,
+// scoped to a single 1Password vault.
+type OnePasswordBackend struct {
+	vault string
+}
+
+// NewOnePasswordBackend returns a Backend backed by the `op` CLI, scoped to vault.
+func NewOnePasswordBackend(vault string) *OnePasswordBackend {
+	return &OnePasswordBackend{vault: vault}
+}
+
+type opField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Read returns the fields of the 1Password item named by itemPath.
+func (b *OnePasswordBackend) Read(itemPath string) (map[string]interface{}, error) {
+	out, err := exec.Command("op", "item", "get", itemPath, "--vault", b.vault, "--format", "json").Output() //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("op item get %q: %w", itemPath, err)
+	}
+
+	var item struct {
+		Fields []opField `json:"fields"`
+	}
+
+	if err := json.Unmarshal(out, &item); err != nil {
+		return nil, fmt.Errorf("parsing op output for %q: %w", itemPath, err)
+	}
+
+	secrets := make(map[string]interface{}, len(item.Fields))
+	for _, f := range item.Fields {
+		secrets[f.Label] = f.Value
+	}
+
+	return secrets, nil
+}
+
+// Write edits the 1Password item named by itemPath, setting each secret as a
+// field, falling back to creating the item first if it doesn't exist yet.
+func (b *OnePasswordBackend) Write(itemPath string, secrets map[string]interface{}) error {
+	fields := make([]string, 0, len(secrets))
+	for k, v := range secrets {
+		fields = append(fields, fmt.Sprintf("%s=%v", k, v))
+	}
+
+	editArgs := append([]string{"item", "edit", itemPath, "--vault", b.vault}, fields...)
+	if err := runOp(editArgs); err == nil {
+		return nil
+	}
+
+	createArgs := append([]string{"item", "create", "--category", "login", "--title", itemPath, "--vault", b.vault}, fields...)
+	if err := runOp(createArgs); err != nil {
+		return fmt.Errorf("op item edit %q failed and op item create %q: %w", itemPath, itemPath, err)
+	}
+
+	return nil
+}
+
+func runOp(args []string) error {
+	cmd := exec.Command("op", args...) //nolint:gosec
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, stderr.String())
+	}
+
+	return nil
+}
+
+// List returns the titles of all items in the vault.
+func (b *OnePasswordBackend) List(_ string) ([]string, error) {
+	out, err := exec.Command("op", "item", "list", "--vault", b.vault, "--format", "json").Output() //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("op item list: %w", err)
+	}
+
+	var items []struct {
+		Title string `json:"title"`
+	}
+
+	if err := json.Unmarshal(out, &items); err != nil {
+		return nil, fmt.Errorf("parsing op item list output: %w", err)
+	}
+
+	titles := make([]string, 0, len(items))
+	for _, it := range items {
+		titles = append(titles, it.Title)
+	}
+
+	return titles, nil
+}
@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/FalcoSuessgott/vkv/pkg/utils"
+)
+
+// SOPSBackend reads and writes secrets to a single SOPS-encrypted YAML file
+// via the `sops` CLI. path arguments are ignored since a SOPS backend is
+// always scoped to exactly one file.
+type SOPSBackend struct {
+	file string
+}
+
+// NewSOPSBackend returns a Backend backed by the SOPS-encrypted file at file.
+func NewSOPSBackend(file string) *SOPSBackend {
+	return &SOPSBackend{file: file}
+}
+
+// Read decrypts and returns the contents of the SOPS file.
+func (b *SOPSBackend) Read(_ string) (map[string]interface{}, error) {
+	out, err := exec.Command("sops", "-d", b.file).Output() //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %q: %w", b.file, err)
+	}
+
+	return utils.FromYAML(out)
+}
+
+// Write encrypts secrets in place over the SOPS file.
+func (b *SOPSBackend) Write(_ string, secrets map[string]interface{}) error {
+	out, err := utils.ToYAML(secrets)
+	if err != nil {
+		return fmt.Errorf("encoding secrets for %q: %w", b.file, err)
+	}
+
+	if err := os.WriteFile(b.file, out, 0o600); err != nil {
+		return fmt.Errorf("writing %q before encryption: %w", b.file, err)
+	}
+
+	cmd := exec.Command("sops", "-e", "-i", b.file) //nolint:gosec
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("encrypting %q: %w: %s", b.file, err, stderr.String())
+	}
+
+	return nil
+}
+
+// List returns the top-level keys stored in the SOPS file.
+func (b *SOPSBackend) List(_ string) ([]string, error) {
+	secrets, err := b.Read("")
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SortMapKeys(secrets), nil
+}
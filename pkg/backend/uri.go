@@ -0,0 +1,48 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+var errUnsupportedScheme = errors.New("unsupported backend scheme")
+
+// New resolves a backend URI such as "vault://secret/foo",
+// "onepassword://Engineering/db-creds", "awssecretsmanager://us-east-1/my-secret",
+// "gcpsecretmanager://my-project/my-secret" or "sops://path/to/file.yaml" into a
+// Backend and the path within it. vaultClient is only used for the "vault" scheme.
+func New(uri string, vaultClient VaultClient) (Backend, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing backend uri %q: %w", uri, err)
+	}
+
+	switch u.Scheme {
+	case "vault":
+		return NewVaultBackend(vaultClient, u.Host), strings.TrimPrefix(u.Path, "/"), nil
+	case "onepassword", "op":
+		return NewOnePasswordBackend(u.Host), strings.TrimPrefix(u.Path, "/"), nil
+	case "awssecretsmanager", "aws":
+		b, err := NewAWSSecretsManagerBackend(context.Background(), u.Host)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return b, strings.TrimPrefix(u.Path, "/"), nil
+	case "gcpsecretmanager", "gcp":
+		b, err := NewGCPSecretManagerBackend(context.Background(), u.Host)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return b, strings.TrimPrefix(u.Path, "/"), nil
+	case "sops":
+		return NewSOPSBackend(path.Join(u.Host, u.Path)), "", nil
+	default:
+		return nil, "", fmt.Errorf("%w: %q", errUnsupportedScheme, u.Scheme)
+	}
+}
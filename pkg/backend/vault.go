@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/FalcoSuessgott/vkv/pkg/utils"
+)
+
+var errInvalidSecretLeaf = errors.New("invalid secret leaf")
+
+// VaultClient is the subset of vkv's Vault client a VaultBackend needs.
+type VaultClient interface {
+	ListRecursive(rootPath, subPath string, withVersions bool) (interface{}, error)
+	WriteSecrets(rootPath, subPath string, secrets map[string]interface{}) error
+}
+
+// VaultBackend adapts vkv's existing Vault client to the Backend interface,
+// scoped to a single KV engine path.
+type VaultBackend struct {
+	client     VaultClient
+	enginePath string
+	writer     io.Writer
+}
+
+// VaultBackendOption configures a VaultBackend.
+type VaultBackendOption func(*VaultBackend)
+
+// WithVaultWriter sets the writer Write reports per-secret progress to.
+// Defaults to io.Discard.
+func WithVaultWriter(w io.Writer) VaultBackendOption {
+	return func(b *VaultBackend) {
+		b.writer = w
+	}
+}
+
+// NewVaultBackend returns a Backend backed by an existing Vault client,
+// scoped to enginePath.
+func NewVaultBackend(client VaultClient, enginePath string, opts ...VaultBackendOption) *VaultBackend {
+	b := &VaultBackend{client: client, enginePath: enginePath, writer: io.Discard}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Read lists all secrets below subPath.
+func (b *VaultBackend) Read(subPath string) (map[string]interface{}, error) {
+	secrets, err := b.client.ListRecursive(b.enginePath, subPath, false)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", path.Join(b.enginePath, subPath), err)
+	}
+
+	return utils.ToMapStringInterface(secrets), nil
+}
+
+// Write flattens secrets and writes each leaf below subPath, stripping the
+// tree's own root element the same way `vkv import` has always done,
+// printing one progress line per secret written.
+func (b *VaultBackend) Write(subPath string, secrets map[string]interface{}) error {
+	transformed := make(map[string]interface{})
+	utils.FlattenMap(secrets, transformed, "")
+
+	root, _ := utils.GetRootElement(secrets)
+
+	for p, m := range transformed {
+		secret, ok := m.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%w: %q is not a map of secrets", errInvalidSecretLeaf, p)
+		}
+
+		leafPath := strings.TrimPrefix(p, root)
+		if subPath != "" {
+			leafPath = path.Join(subPath, leafPath)
+		}
+
+		fmt.Fprintf(b.writer, "writing secret %q\n", path.Join(b.enginePath, leafPath))
+
+		if err := b.client.WriteSecrets(b.enginePath, leafPath, secret); err != nil {
+			return fmt.Errorf("writing secret %q: %w", p, err)
+		}
+	}
+
+	return nil
+}
+
+// List returns the top-level paths stored below subPath.
+func (b *VaultBackend) List(subPath string) ([]string, error) {
+	secrets, err := b.Read(subPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SortMapKeys(secrets), nil
+}
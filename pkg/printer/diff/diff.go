@@ -0,0 +1,233 @@
+// Package diff builds structured three-way-merge style diffs between two
+// secret trees (as produced by utils.UnflattenMap) so that callers such as
+// `vkv import --dry-run` can show operators exactly what would change
+// instead of a flat "changed/unchanged" verdict.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/FalcoSuessgott/vkv/pkg/printer"
+	"github.com/FalcoSuessgott/vkv/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeType classifies a single key/value diff record.
+type ChangeType string
+
+const (
+	// Added marks a key that is only present in the new secrets.
+	Added ChangeType = "ADDED"
+	// Removed marks a key that is only present in the existing secrets.
+	Removed ChangeType = "REMOVED"
+	// Changed marks a key whose value differs between old and new.
+	Changed ChangeType = "CHANGED"
+	// Unchanged marks a key whose value is identical in both trees.
+	Unchanged ChangeType = "UNCHANGED"
+
+	// TextFormat renders the diff as human readable lines.
+	TextFormat = "text"
+	// JSONFormat renders the diff as a JSON array of records.
+	JSONFormat = "json"
+	// YAMLFormat renders the diff as a YAML array of records.
+	YAMLFormat = "yaml"
+)
+
+// Record describes a single path/key diff between two secret trees.
+type Record struct {
+	Path     string     `json:"path" yaml:"path"`
+	Key      string     `json:"key" yaml:"key"`
+	Type     ChangeType `json:"type" yaml:"type"`
+	OldValue string     `json:"old,omitempty" yaml:"old,omitempty"`
+	NewValue string     `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// Option configures a Differ.
+type Option func(*Differ)
+
+// Differ computes and renders diffs between two secret trees, reusing the
+// same value masking semantics as pkg/printer.
+type Differ struct {
+	showValues  bool
+	maskMode    printer.MaskMode
+	valueLength int
+	salt        string
+}
+
+// ShowValues disables masking of old/new values in the resulting diff.
+func ShowValues(b bool) Option {
+	return func(d *Differ) {
+		d.showValues = b
+	}
+}
+
+// MaskMode selects how masked values are rendered - MaskAsterisks (default),
+// MaskFingerprint or MaskLengthPreserving - matching pkg/printer's MaskMode
+// so a diff and a result preview mask the same value identically.
+func MaskMode(m printer.MaskMode) Option {
+	return func(d *Differ) {
+		d.maskMode = m
+	}
+}
+
+// MaxValueLength caps how many "*" MaskAsterisks renders. Defaults to
+// printer.MaxValueLength.
+func MaxValueLength(n int) Option {
+	return func(d *Differ) {
+		d.valueLength = n
+	}
+}
+
+// Salt sets the salt used when fingerprinting masked values, keyed the same
+// way as pkg/printer's MaskFingerprint so the same value renders to the same
+// fingerprint in both a diff and a result preview. Defaults to
+// printer.DefaultMaskSalt(). An empty salt is ignored, leaving the default in
+// place - callers that need a diff and a Printer to agree must resolve the
+// salt once (e.g. via printer.DefaultMaskSalt()) and pass it to both.
+func Salt(s string) Option {
+	return func(d *Differ) {
+		if s != "" {
+			d.salt = s
+		}
+	}
+}
+
+// New returns a Differ configured with the given options.
+func New(opts ...Option) *Differ {
+	d := &Differ{
+		salt:        printer.DefaultMaskSalt(),
+		valueLength: printer.MaxValueLength,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Compare walks old and new (both in the path -> {key: value} shape used
+// throughout vkv) and returns one Record per key, in stable path/key order.
+func (d *Differ) Compare(old, new map[string]interface{}) []Record { //nolint: varnamelen
+	var records []Record
+
+	for _, p := range utils.SortMapKeys(mergeTopLevelKeys(old, new)) {
+		oldLeaf, _ := old[p].(map[string]interface{})
+		newLeaf, _ := new[p].(map[string]interface{})
+
+		for _, k := range utils.SortMapKeys(mergeTopLevelKeys(oldLeaf, newLeaf)) {
+			oldVal, oldOK := oldLeaf[k]
+			newVal, newOK := newLeaf[k]
+
+			records = append(records, d.compareValue(p, k, oldVal, oldOK, newVal, newOK))
+		}
+	}
+
+	return records
+}
+
+func (d *Differ) compareValue(path, key string, oldVal interface{}, oldOK bool, newVal interface{}, newOK bool) Record {
+	r := Record{Path: path, Key: key}
+
+	switch {
+	case !oldOK && newOK:
+		r.Type = Added
+		r.NewValue = d.mask(newVal)
+	case oldOK && !newOK:
+		r.Type = Removed
+		r.OldValue = d.mask(oldVal)
+	case fmt.Sprint(oldVal) != fmt.Sprint(newVal):
+		r.Type = Changed
+		r.OldValue = d.mask(oldVal)
+		r.NewValue = d.mask(newVal)
+	default:
+		r.Type = Unchanged
+		r.OldValue = d.mask(oldVal)
+		r.NewValue = r.OldValue
+	}
+
+	return r
+}
+
+// HasChanges reports whether records contain any ADDED, REMOVED or CHANGED entry.
+func HasChanges(records []Record) bool {
+	for _, r := range records {
+		if r.Type != Unchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Print renders records to w in the given format (text, json or yaml).
+func (d *Differ) Print(w io.Writer, format string, records []Record) error {
+	switch format {
+	case JSONFormat:
+		out, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "%s\n", out)
+	case YAMLFormat:
+		out, err := yaml.Marshal(records)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "%s", out)
+	default:
+		d.printText(w, records)
+	}
+
+	return nil
+}
+
+func (d *Differ) printText(w io.Writer, records []Record) {
+	if !HasChanges(records) {
+		fmt.Fprintln(w, "no changes detected")
+
+		return
+	}
+
+	for _, r := range records {
+		switch r.Type {
+		case Added:
+			fmt.Fprintf(w, "%s: %s: %s %s\n", r.Path, r.Key, Added, r.NewValue)
+		case Removed:
+			fmt.Fprintf(w, "%s: %s: %s %s\n", r.Path, r.Key, Removed, r.OldValue)
+		case Changed:
+			fmt.Fprintf(w, "%s: %s: %s -> %s\n", r.Path, r.Key, r.OldValue, r.NewValue)
+		case Unchanged:
+			continue
+		}
+	}
+}
+
+// mask renders v via printer.MaskValue under the Differ's configured
+// MaskMode, so a masked value printed by the diff and by a Printer showing
+// the same mode are byte-for-byte identical.
+func (d *Differ) mask(v interface{}) string {
+	if d.showValues {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return printer.MaskValue(d.maskMode, fmt.Sprintf("%v", v), d.valueLength, d.salt)
+}
+
+func mergeTopLevelKeys(a, b map[string]interface{}) map[string]interface{} {
+	keys := make(map[string]interface{}, len(a)+len(b))
+
+	for k := range a {
+		keys[k] = nil
+	}
+
+	for k := range b {
+		keys[k] = nil
+	}
+
+	return keys
+}
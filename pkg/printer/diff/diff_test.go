@@ -0,0 +1,114 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/FalcoSuessgott/vkv/pkg/printer"
+)
+
+func recordFor(records []Record, path, key string) (Record, bool) {
+	for _, r := range records {
+		if r.Path == path && r.Key == key {
+			return r, true
+		}
+	}
+
+	return Record{}, false
+}
+
+func TestDiffer_Compare_Classification(t *testing.T) {
+	old := map[string]interface{}{
+		"app/db": map[string]interface{}{
+			"user": "admin",
+			"pass": "old-pass",
+			"gone": "bye",
+		},
+	}
+	newSecrets := map[string]interface{}{
+		"app/db": map[string]interface{}{
+			"user": "admin",
+			"pass": "new-pass",
+			"new":  "hi",
+		},
+	}
+
+	d := New(ShowValues(true))
+	records := d.Compare(old, newSecrets)
+
+	tests := []struct {
+		key  string
+		want ChangeType
+	}{
+		{"user", Unchanged},
+		{"pass", Changed},
+		{"gone", Removed},
+		{"new", Added},
+	}
+
+	for _, tt := range tests {
+		r, ok := recordFor(records, "app/db", tt.key)
+		if !ok {
+			t.Fatalf("no record for key %q", tt.key)
+		}
+
+		if r.Type != tt.want {
+			t.Errorf("key %q: type = %s, want %s", tt.key, r.Type, tt.want)
+		}
+	}
+
+	if !HasChanges(records) {
+		t.Fatal("HasChanges = false, want true")
+	}
+}
+
+func TestDiffer_Compare_NoChanges(t *testing.T) {
+	secrets := map[string]interface{}{
+		"app/db": map[string]interface{}{"user": "admin"},
+	}
+
+	d := New(ShowValues(true))
+	records := d.Compare(secrets, secrets)
+
+	if HasChanges(records) {
+		t.Fatal("HasChanges = true for identical trees, want false")
+	}
+}
+
+func TestDiffer_Mask_MatchesPrinterMaskValue(t *testing.T) {
+	old := map[string]interface{}{"p": map[string]interface{}{"k": "s3cr3t-value"}}
+	newSecrets := map[string]interface{}{"p": map[string]interface{}{"k": "s3cr3t-changed"}}
+
+	tests := []printer.MaskMode{printer.MaskAsterisks, printer.MaskFingerprint, printer.MaskLengthPreserving}
+
+	for _, mode := range tests {
+		d := New(MaskMode(mode), Salt("salt"), MaxValueLength(4))
+		records := d.Compare(old, newSecrets)
+
+		r, ok := recordFor(records, "p", "k")
+		if !ok {
+			t.Fatalf("mode %v: no record for key", mode)
+		}
+
+		want := printer.MaskValue(mode, "s3cr3t-changed", 4, "salt")
+		if r.NewValue != want {
+			t.Errorf("mode %v: diff masked %q, printer.MaskValue masked %q", mode, r.NewValue, want)
+		}
+	}
+}
+
+func TestDiffer_Mask_DefaultsToAsterisksLikePrinter(t *testing.T) {
+	old := map[string]interface{}{"p": map[string]interface{}{"k": "old"}}
+	newSecrets := map[string]interface{}{"p": map[string]interface{}{"k": "new-value"}}
+
+	d := New()
+	records := d.Compare(old, newSecrets)
+
+	r, ok := recordFor(records, "p", "k")
+	if !ok {
+		t.Fatal("no record for key")
+	}
+
+	if r.NewValue == "" || r.NewValue[0] != '*' {
+		t.Errorf("default mask mode rendered %q, want asterisks (matching printer's default)", r.NewValue)
+	}
+}
@@ -1,9 +1,17 @@
 package printer
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	encjson "encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/FalcoSuessgott/vkv/pkg/utils"
@@ -11,17 +19,47 @@ import (
 
 type outputFormat int
 
+// MaskMode controls how secret values are masked before being printed.
+type MaskMode int
+
 const (
 	maskChar = "*"
 
 	// MaxValueLength maximum length of passwords.
 	MaxValueLength = 12
 
+	// k8sAPIVersion is the apiVersion used for the generated Secret manifests.
+	k8sAPIVersion = "v1"
+
+	// fingerprintHexLen number of hex chars kept from the HMAC-SHA-256 fingerprint.
+	fingerprintHexLen = 8
+
+	// maskSaltEnvVar overrides the per-invocation random salt used for MaskFingerprint.
+	maskSaltEnvVar = "VKV_MASK_SALT"
+
+	// defaultChunkSize is how many top-level paths are buffered before a
+	// yaml/json chunk is flushed to the writer.
+	defaultChunkSize = 500
+
 	yaml outputFormat = iota
 	json
 	export
+	dotenv
+	k8s
+	tfvars
+	hcl
+
+	// MaskAsterisks masks values with a fixed number of "*" (default, current behaviour).
+	MaskAsterisks MaskMode = iota
+	// MaskFingerprint masks values with a stable "sha256:xxxxxxxx" fingerprint so
+	// operators can tell identical/changed values apart without seeing the plaintext.
+	MaskFingerprint
+	// MaskLengthPreserving masks values with as many "*" as the value is long.
+	MaskLengthPreserving
 )
 
+var identifierReplacer = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
 var defaultWriter = os.Stdout
 
 // Option list of available options for modifying the output.
@@ -29,13 +67,29 @@ type Option func(*Printer)
 
 // Printer struct that holds all options used for displaying the secrets.
 type Printer struct {
-	secrets     map[string]interface{}
-	format      outputFormat
-	writer      io.Writer
-	onlyKeys    bool
-	onlyPaths   bool
-	showSecrets bool
-	valueLength int
+	secrets       map[string]interface{}
+	format        outputFormat
+	writer        io.Writer
+	onlyKeys      bool
+	onlyPaths     bool
+	showSecrets   bool
+	valueLength   int
+	k8sName       string
+	k8sNamespace  string
+	k8sStringData bool
+	maskMode      MaskMode
+	maskSalt      string
+	chunkSize     int
+	streaming     bool
+	ctx           context.Context //nolint:containedctx
+}
+
+// PathSecrets is a single top-level path and its secrets, as pushed by a
+// streaming source (e.g. a Vault list walker) instead of being accumulated
+// into one giant map up front.
+type PathSecrets struct {
+	Path    string
+	Secrets map[string]interface{}
 }
 
 // CustomValueLength option for trimming down the output of secrets.
@@ -91,6 +145,50 @@ func ToExportFormat(b bool) Option {
 	}
 }
 
+// ToDotenv outputformat to a dotenv (KEY=VALUE) file.
+func ToDotenv(b bool) Option {
+	return func(p *Printer) {
+		if b {
+			p.format = dotenv
+		}
+	}
+}
+
+// ToK8sSecret outputformat to a Kubernetes v1 Secret manifest named name in namespace ns.
+func ToK8sSecret(name, ns string) Option {
+	return func(p *Printer) {
+		p.format = k8s
+		p.k8sName = name
+		p.k8sNamespace = ns
+	}
+}
+
+// K8sStringData renders the generated Kubernetes Secret's values under
+// "stringData" in plain text instead of base64-encoded "data".
+func K8sStringData(b bool) Option {
+	return func(p *Printer) {
+		p.k8sStringData = b
+	}
+}
+
+// ToTFVars outputformat to Terraform tfvars variable assignments.
+func ToTFVars(b bool) Option {
+	return func(p *Printer) {
+		if b {
+			p.format = tfvars
+		}
+	}
+}
+
+// ToHCL outputformat to nested HCL blocks mirroring the path hierarchy.
+func ToHCL(b bool) Option {
+	return func(p *Printer) {
+		if b {
+			p.format = hcl
+		}
+	}
+}
+
 // WithWriter option for passing a custom io.Writer.
 func WithWriter(w io.Writer) Option {
 	return func(p *Printer) {
@@ -105,29 +203,133 @@ func ShowSecrets(b bool) Option {
 	}
 }
 
+// WithMaskMode selects how values are masked: MaskAsterisks (default),
+// MaskFingerprint or MaskLengthPreserving.
+func WithMaskMode(m MaskMode) Option {
+	return func(p *Printer) {
+		p.maskMode = m
+	}
+}
+
+// WithMaskSalt sets the salt used for MaskFingerprint. If unset, the
+// VKV_MASK_SALT environment variable is used, falling back to a random
+// salt generated for the lifetime of the Printer.
+func WithMaskSalt(s string) Option {
+	return func(p *Printer) {
+		p.maskSalt = s
+	}
+}
+
+// StreamingChunkSize sets how many top-level paths are buffered by Out/Stream
+// before a yaml/json chunk is flushed to the writer. Implies Streaming(true).
+func StreamingChunkSize(n int) Option {
+	return func(p *Printer) {
+		if n > 0 {
+			p.chunkSize = n
+			p.streaming = true
+		}
+	}
+}
+
+// Streaming opts into chunked yaml/json output: documents/arrays are flushed
+// every chunkSize top-level paths instead of being serialized all at once.
+// This changes the on-disk shape of yaml (multiple "---"-separated documents)
+// and json (an array of single-key objects instead of one object) once a tree
+// exceeds chunkSize, so it must be explicitly requested - the default (Streaming
+// is never called, or called with false) keeps the original single-document/
+// single-object shape every existing consumer expects.
+func Streaming(b bool) Option {
+	return func(p *Printer) {
+		p.streaming = b
+	}
+}
+
+// WithContext sets the context Out uses to drive its streaming path,
+// letting a caller cancel a large chunked export early. Defaults to
+// context.Background(), i.e. no cancellation.
+func WithContext(ctx context.Context) Option { //nolint:revive
+	return func(p *Printer) {
+		p.ctx = ctx
+	}
+}
+
 // NewPrinter return a new printer struct.
 func NewPrinter(m map[string]interface{}, opts ...Option) *Printer {
 	p := &Printer{
 		secrets:     m,
 		writer:      defaultWriter,
 		valueLength: MaxValueLength,
+		chunkSize:   defaultChunkSize,
+		ctx:         context.Background(),
 	}
 
 	for _, opt := range opts {
 		opt(p)
 	}
 
-	if !p.showSecrets {
+	if p.maskSalt == "" {
+		p.maskSalt = DefaultMaskSalt()
+	}
+
+	// Streaming masks each PathSecrets as it is consumed (see Stream), so
+	// masking here would double-mask the same values when Out() drives
+	// Stream via streamChunked, which it only does for yaml and json; every
+	// other format is masked here even when streaming.
+	if !p.showSecrets && !(p.streaming && (p.format == yaml || p.format == json)) {
 		p.maskSecrets()
 	}
 
 	return p
 }
 
-// Out prints out the secrets according all configured options.
+// DefaultMaskSalt returns VKV_MASK_SALT if set, otherwise a random salt that
+// is only stable for this process invocation. Callers that need the same
+// fingerprints to show up in more than one place (e.g. `vkv import --dry-run`
+// printing both a diff and a result preview) must resolve this once and pass
+// the result to every Printer/Differ they construct, rather than letting
+// each one resolve its own default and risk picking different random salts.
+//
+// It panics if the system CSPRNG fails. That should never happen on any
+// supported platform, and falling back to a fixed, known salt would make
+// every MaskFingerprint value predictable - defeating the point of masking -
+// so this fails closed instead of silently weakening it.
+func DefaultMaskSalt() string {
+	if salt := os.Getenv(maskSaltEnvVar); salt != "" {
+		return salt
+	}
+
+	b := make([]byte, sha256.Size)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("printer: generating random mask salt: %v", err))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Fingerprint renders a stable, non-reversible "sha256:xxxxxxxx" identifier
+// for value, keyed by salt, so operators can grep/diff masked output and spot
+// duplicated or drifted credentials without ever seeing plaintext. It is the
+// single fingerprint implementation shared by Printer and pkg/printer/diff,
+// so the same value and salt always render identically in both places.
+func Fingerprint(value, salt string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(value))
+
+	return "sha256:" + hex.EncodeToString(mac.Sum(nil))[:fingerprintHexLen]
+}
+
+// Out prints out the secrets according all configured options. yaml and json
+// are rendered as a single document/object, matching every existing consumer
+// of vkv's output, unless Streaming/StreamingChunkSize opted into chunked
+// output, in which case they're flushed in chunks of chunkSize top-level
+// paths so very large trees don't have to be serialized all at once.
 func (p *Printer) Out() error {
 	switch p.format {
 	case yaml:
+		if p.streaming {
+			return p.streamChunked(p.ctx)
+		}
+
 		out, err := utils.ToYAML(p.secrets)
 		if err != nil {
 			return err
@@ -135,6 +337,10 @@ func (p *Printer) Out() error {
 
 		fmt.Fprintf(p.writer, "%s", string(out))
 	case json:
+		if p.streaming {
+			return p.streamChunked(p.ctx)
+		}
+
 		out, err := utils.ToJSON(p.secrets)
 		if err != nil {
 			return err
@@ -147,6 +353,14 @@ func (p *Printer) Out() error {
 				fmt.Fprintf(p.writer, "export %s=%v\n", k, v)
 			}
 		}
+	case dotenv:
+		p.printDotenv()
+	case k8s:
+		return p.printK8sSecret()
+	case tfvars:
+		p.printTFVars()
+	case hcl:
+		p.printHCL()
 	default:
 		for _, k := range utils.SortMapKeys(p.secrets) {
 			fmt.Fprintf(p.writer, "%s\n", k)
@@ -157,6 +371,179 @@ func (p *Printer) Out() error {
 	return nil
 }
 
+func (p *Printer) pathValue(path string) interface{} {
+	return p.secrets[path]
+}
+
+// streamYAML writes keys as a series of YAML documents separated by "---",
+// each document holding up to chunkSize top-level paths.
+func (p *Printer) streamYAML(keys []string, get func(string) interface{}) error {
+	for i := 0; i < len(keys); i += p.chunkSize {
+		end := i + p.chunkSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		chunk := make(map[string]interface{}, end-i)
+		for _, k := range keys[i:end] {
+			chunk[k] = get(k)
+		}
+
+		out, err := utils.ToYAML(chunk)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Fprintln(p.writer, "---")
+		}
+
+		fmt.Fprintf(p.writer, "%s", string(out))
+	}
+
+	return nil
+}
+
+// streamJSON writes keys as comma-separated JSON array elements, encoding one
+// top-level path object at a time via json.Encoder instead of marshalling the
+// whole tree at once. Stream writes the enclosing brackets and the commas
+// between chunks.
+func (p *Printer) streamJSON(keys []string, get func(string) interface{}) error {
+	enc := encjson.NewEncoder(p.writer)
+
+	for i, k := range keys {
+		if i > 0 {
+			fmt.Fprint(p.writer, ",")
+		}
+
+		if err := enc.Encode(map[string]interface{}{k: get(k)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// streamChunked adapts p.secrets into a PathSecrets channel and drives it
+// through Stream. It's the producer Out() uses when Streaming is enabled but
+// the caller only has an already-materialized tree: it bounds serialization
+// peak but, unlike a true lazy producer (e.g. a Vault list walker pushing
+// paths as they're fetched), it does not reduce retention, since p.secrets is
+// already fully in memory by the time Out() is called. Callers that walk a
+// large store incrementally should build their own channel and call Stream
+// directly to get the full memory benefit.
+func (p *Printer) streamChunked(ctx context.Context) error {
+	ch := make(chan PathSecrets)
+
+	go func() {
+		defer close(ch)
+
+		for _, k := range utils.SortMapKeys(p.secrets) {
+			secrets, ok := p.secrets[k].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			select {
+			case ch <- PathSecrets{Path: k, Secrets: secrets}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return p.Stream(ctx, ch)
+}
+
+// Stream consumes PathSecrets from ch, masking and rendering each one in
+// chunks of chunkSize. Unlike Out(), it never requires the full tree to be
+// materialized up front: a producer that fetches paths incrementally (e.g. a
+// Vault list walker) can push each PathSecrets as soon as it arrives instead
+// of accumulating everything into one giant map first. It returns as soon as
+// ch is closed, or ctx is cancelled after flushing whatever has already been
+// buffered.
+func (p *Printer) Stream(ctx context.Context, ch <-chan PathSecrets) error {
+	buf := make(map[string]interface{}, p.chunkSize)
+	flushed := false
+
+	flush := func() error {
+		if len(buf) == 0 {
+			return nil
+		}
+
+		keys := utils.SortMapKeys(buf)
+		get := func(k string) interface{} { return buf[k] }
+
+		var err error
+
+		switch p.format {
+		case yaml:
+			if flushed {
+				fmt.Fprintln(p.writer, "---")
+			}
+
+			err = p.streamYAML(keys, get)
+		case json:
+			if flushed {
+				fmt.Fprint(p.writer, ",")
+			} else {
+				fmt.Fprint(p.writer, "[")
+			}
+
+			err = p.streamJSON(keys, get)
+		default:
+			p.secrets = buf
+			err = p.Out()
+		}
+
+		flushed = true
+
+		for k := range buf {
+			delete(buf, k)
+		}
+
+		return err
+	}
+
+	finish := func() error {
+		err := flush()
+		if p.format == json && flushed {
+			fmt.Fprintln(p.writer, "]")
+		}
+
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := finish(); err != nil {
+				return err
+			}
+
+			return ctx.Err()
+		case ps, ok := <-ch:
+			if !ok {
+				return finish()
+			}
+
+			if !p.showSecrets {
+				for k, v := range ps.Secrets {
+					ps.Secrets[k] = p.maskValue(fmt.Sprintf("%v", v))
+				}
+			}
+
+			buf[ps.Path] = ps.Secrets
+
+			if len(buf) >= p.chunkSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
 func (p *Printer) printOnlykeys() {
 	for k := range p.secrets {
 		m, ok := p.secrets[k].(map[string]interface{})
@@ -185,13 +572,34 @@ func (p *Printer) maskSecrets() {
 
 		for k := range m {
 			secret := fmt.Sprintf("%v", m[k])
+			m[k] = p.maskValue(secret)
+		}
+	}
+}
 
-			if len(secret) > p.valueLength {
-				m[k] = strings.Repeat(maskChar, p.valueLength)
-			} else {
-				m[k] = strings.Repeat(maskChar, len(secret))
-			}
+func (p *Printer) maskValue(secret string) string {
+	return MaskValue(p.maskMode, secret, p.valueLength, p.maskSalt)
+}
+
+// MaskValue renders value per mode: MaskAsterisks caps at valueLength "*"
+// (or fewer if value is shorter), MaskLengthPreserving masks with exactly
+// len(value) "*", and MaskFingerprint renders a stable Fingerprint keyed by
+// salt. It is the single masking implementation shared by Printer and
+// pkg/printer/diff, so a value masks identically everywhere vkv prints it.
+func MaskValue(mode MaskMode, value string, valueLength int, salt string) string {
+	switch mode {
+	case MaskFingerprint:
+		return Fingerprint(value, salt)
+	case MaskLengthPreserving:
+		return strings.Repeat(maskChar, len(value))
+	case MaskAsterisks:
+		fallthrough
+	default:
+		if len(value) > valueLength {
+			return strings.Repeat(maskChar, valueLength)
 		}
+
+		return strings.Repeat(maskChar, len(value))
 	}
 }
 
@@ -209,3 +617,115 @@ func (p *Printer) printSecrets(s interface{}) {
 		}
 	}
 }
+
+func (p *Printer) printDotenv() {
+	for _, s := range utils.SortMapKeys(p.secrets) {
+		m, ok := p.secrets[s].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, k := range utils.SortMapKeys(m) {
+			fmt.Fprintf(p.writer, "%s=%s\n", strings.ToUpper(identifierReplacer.ReplaceAllString(k, "_")), escapeDotenvValue(fmt.Sprintf("%v", m[k])))
+		}
+	}
+}
+
+func (p *Printer) printTFVars() {
+	for _, s := range utils.SortMapKeys(p.secrets) {
+		m, ok := p.secrets[s].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, k := range utils.SortMapKeys(m) {
+			fmt.Fprintf(p.writer, "%s = %q\n", identifierReplacer.ReplaceAllString(k, "_"), fmt.Sprintf("%v", m[k]))
+		}
+	}
+}
+
+func (p *Printer) printHCL() {
+	for _, s := range utils.SortMapKeys(p.secrets) {
+		m, ok := p.secrets[s].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(p.writer, "path %q {\n", s)
+
+		for _, k := range utils.SortMapKeys(m) {
+			fmt.Fprintf(p.writer, "  %s = %q\n", identifierReplacer.ReplaceAllString(k, "_"), fmt.Sprintf("%v", m[k]))
+		}
+
+		fmt.Fprintln(p.writer, "}")
+	}
+}
+
+// printK8sSecret renders one v1 Secret manifest per top-level path, separated
+// by YAML document markers so multiple paths can be applied with a single
+// `kubectl apply -f -`.
+func (p *Printer) printK8sSecret() error {
+	for i, s := range utils.SortMapKeys(p.secrets) {
+		m, ok := p.secrets[s].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		secret := map[string]interface{}{
+			"apiVersion": k8sAPIVersion,
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name":      p.k8sName,
+				"namespace": p.k8sNamespace,
+			},
+			"type": "Opaque",
+		}
+
+		data := make(map[string]interface{}, len(m))
+
+		for k, v := range m {
+			value := fmt.Sprintf("%v", v)
+
+			if p.k8sStringData {
+				data[k] = value
+			} else {
+				data[k] = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+		}
+
+		if p.k8sStringData {
+			secret["stringData"] = data
+		} else {
+			secret["data"] = data
+		}
+
+		out, err := utils.ToYAML(secret)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Fprintln(p.writer, "---")
+		}
+
+		fmt.Fprintf(p.writer, "%s", string(out))
+	}
+
+	return nil
+}
+
+// escapeDotenvValue quotes v if it contains characters that are unsafe to
+// leave unquoted in a dotenv file.
+func escapeDotenvValue(v string) string {
+	if v == "" {
+		return "\"\""
+	}
+
+	if !strings.ContainsAny(v, " \t\"'\n#=") {
+		return v
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	return "\"" + replacer.Replace(v) + "\""
+}
@@ -0,0 +1,125 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFingerprint_StableAndSaltDependent(t *testing.T) {
+	a := Fingerprint("s3cr3t", "salt-a")
+	b := Fingerprint("s3cr3t", "salt-a")
+
+	if a != b {
+		t.Fatalf("same value/salt produced different fingerprints: %q != %q", a, b)
+	}
+
+	if !strings.HasPrefix(a, "sha256:") {
+		t.Fatalf("fingerprint %q missing sha256: prefix", a)
+	}
+
+	if len(strings.TrimPrefix(a, "sha256:")) != fingerprintHexLen {
+		t.Fatalf("fingerprint %q does not have %d hex chars", a, fingerprintHexLen)
+	}
+
+	c := Fingerprint("s3cr3t", "salt-b")
+	if a == c {
+		t.Fatalf("different salts produced the same fingerprint: %q", a)
+	}
+}
+
+func TestMaskValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  MaskMode
+		value string
+	}{
+		{"asterisks short", MaskAsterisks, "abc"},
+		{"asterisks capped", MaskAsterisks, "abcdefghijklmnop"},
+		{"length preserving", MaskLengthPreserving, "abcdefghijklmnop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MaskValue(tt.mode, tt.value, 4, "salt")
+
+			if strings.Trim(got, "*") != "" {
+				t.Fatalf("mask of %q produced non-asterisk output: %q", tt.value, got)
+			}
+
+			switch tt.mode {
+			case MaskAsterisks:
+				if len(got) > 4 {
+					t.Fatalf("asterisks mask exceeded valueLength: %q", got)
+				}
+			case MaskLengthPreserving:
+				if len(got) != len(tt.value) {
+					t.Fatalf("length-preserving mask changed length: %q vs %q", got, tt.value)
+				}
+			}
+		})
+	}
+
+	fp := MaskValue(MaskFingerprint, "abc", 4, "salt")
+	if !strings.HasPrefix(fp, "sha256:") {
+		t.Fatalf("fingerprint mode didn't fingerprint: %q", fp)
+	}
+}
+
+func TestPrinter_DotenvTFVarsHCL(t *testing.T) {
+	secrets := map[string]interface{}{
+		"app/db": map[string]interface{}{
+			"user": "admin",
+		},
+	}
+
+	var buf bytes.Buffer
+
+	NewPrinter(secrets, ToDotenv(true), ShowSecrets(true), WithWriter(&buf)).Out() //nolint:errcheck
+
+	if got := buf.String(); got != "USER=admin\n" {
+		t.Fatalf("dotenv output = %q, want %q", got, "USER=admin\n")
+	}
+
+	buf.Reset()
+	NewPrinter(secrets, ToTFVars(true), ShowSecrets(true), WithWriter(&buf)).Out() //nolint:errcheck
+
+	if got := buf.String(); got != "user = \"admin\"\n" {
+		t.Fatalf("tfvars output = %q, want %q", got, "user = \"admin\"\n")
+	}
+
+	buf.Reset()
+	NewPrinter(secrets, ToHCL(true), ShowSecrets(true), WithWriter(&buf)).Out() //nolint:errcheck
+
+	want := "path \"app/db\" {\n  user = \"admin\"\n}\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("hcl output = %q, want %q", got, want)
+	}
+}
+
+func TestPrinter_Stream_ChunkBoundaries(t *testing.T) {
+	var buf bytes.Buffer
+
+	p := NewPrinter(nil, ToYAML(true), ShowSecrets(true), WithWriter(&buf))
+	p.chunkSize = 2
+
+	ch := make(chan PathSecrets)
+
+	go func() {
+		defer close(ch)
+
+		for i := 0; i < 5; i++ {
+			ch <- PathSecrets{Path: string(rune('a' + i)), Secrets: map[string]interface{}{"k": i}}
+		}
+	}()
+
+	if err := p.Stream(context.Background(), ch); err != nil {
+		t.Fatalf("Stream returned an error: %v", err)
+	}
+
+	// 5 paths at chunkSize 2 flush as 2, 2, 1 -> 3 documents, so 2 "---" separators.
+	if got := strings.Count(buf.String(), "---"); got != 2 {
+		t.Fatalf("expected 2 chunk boundaries, got %d in output:\n%s", got, buf.String())
+	}
+}